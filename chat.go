@@ -3,6 +3,7 @@ package chat
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -14,20 +15,66 @@ import (
 type LLMInterface interface {
 	// the context window capacity of the LLM
 	MaxTokens() int
-	// estimates how many tokens are used by the messages
-	TokenEstimate(messages []Message) (int, error)
+	// estimates how many tokens the messages will use; backends that can ask the
+	// provider for an exact count set TokenCount.Estimated to false
+	EstimateTokens(messages []Message) (TokenCount, error)
 	// streams the response of the LLM to the messages
 	Streaming(messages []Message, stream io.Writer) (*Response, error)
+	// like Streaming, but lets the model invoke tools from the registry as it goes,
+	// looping until it emits a final answer instead of a tool call
+	StreamingWithTools(messages []Message, tools *ToolRegistry, stream io.Writer) (*Response, error)
+	// StreamingEvents is Streaming's underlying primitive: a channel of typed
+	// StreamEvents (text, tool-call, usage, finish/error) rather than raw bytes
+	// written to an io.Writer, letting callers distinguish assistant text from
+	// tool-call arguments and usage metadata as they arrive. Streaming is
+	// implemented in terms of it.
+	StreamingEvents(ctx context.Context, messages []Message) (<-chan StreamEvent, error)
+}
+
+// TokenCount reports how many tokens a request used or is expected to use.
+// Estimated is true when Prompt/Completion/Total come from a word-ratio heuristic
+// rather than a count reported or computed by the provider.
+type TokenCount struct {
+	Prompt     int
+	Completion int
+	Total      int
+	Estimated  bool
+}
+
+// Add sums two TokenCounts, e.g. across the turns of a tool-calling loop.
+// The result is Estimated if either operand is.
+func (t TokenCount) Add(o TokenCount) TokenCount {
+	return TokenCount{
+		Prompt:     t.Prompt + o.Prompt,
+		Completion: t.Completion + o.Completion,
+		Total:      t.Total + o.Total,
+		Estimated:  t.Estimated || o.Estimated,
+	}
 }
 
 type Message struct {
 	Role    Role
 	Content string
+
+	// set on an assistant Message when the model requested one or more tool invocations
+	ToolCalls []ToolCall
+
+	// set on a RoleTool Message replying to a prior ToolCall
+	ToolCallID string
+	ToolName   string
 }
 
 type Response struct {
 	Content      string
 	FinishReason FinishReason
+	// Usage covers the whole call, including every turn of a StreamingWithTools
+	// tool-calling loop, not just the final completion.
+	Usage TokenCount
+
+	// when tool-calling was used, the full sequence of messages generated this turn
+	// (assistant tool calls, their RoleTool results, and the final assistant message),
+	// suitable for appending to the conversation so it round-trips on the next call
+	Messages []Message
 }
 
 //go:generate stringer -type=Role
@@ -38,6 +85,7 @@ const (
 	RoleSystem
 	RoleUser
 	RoleAssistant
+	RoleTool
 )
 
 //go:generate stringer -type=FinishReason
@@ -126,6 +174,14 @@ func Streaming(config APIConfig, promptFiles ...File) error {
 				return fmt.Errorf("bad finish reason: %q", r.FinishReason)
 			}
 			fmt.Println()
+			if config.Print {
+				u := r.Usage
+				kind := "estimated"
+				if !u.Estimated {
+					kind = "actual"
+				}
+				fmt.Printf("[tokens %s: prompt=%d completion=%d total=%d]\n", kind, u.Prompt, u.Completion, u.Total)
+			}
 		}
 		init = true
 		fmt.Print("> ")
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"unicode"
 
@@ -37,12 +38,13 @@ func countWords(s string) int {
 }
 
 // rough estimate at 3/4 token per byte
-func (claudeInterface) TokenEstimate(messages []Message) (int, error) {
+func (claudeInterface) EstimateTokens(messages []Message) (TokenCount, error) {
 	prompt, err := claudePrompt(messages)
 	if err != nil {
-		return 0, err
+		return TokenCount{}, err
 	}
-	return int(4.0 / 3.0 * float64(countWords(prompt))), nil
+	n := int(4.0 / 3.0 * float64(countWords(prompt)))
+	return TokenCount{Prompt: n, Total: n, Estimated: true}, nil
 }
 
 func claudePrompt(messages []Message) (string, error) {
@@ -55,6 +57,8 @@ func claudePrompt(messages []Message) (string, error) {
 			fmt.Fprintf(prompt, "%s %s\n", humanPrompt, m.Content)
 		case RoleAssistant:
 			fmt.Fprintf(prompt, "%s %s\n", assistantPrompt, m.Content)
+		case RoleTool:
+			fmt.Fprintf(prompt, "%s <function_results>\n<result>\n<tool_name>%s</tool_name>\n<output>\n%s\n</output>\n</result>\n</function_results>\n", humanPrompt, m.ToolName, m.Content)
 		default:
 			return "", fmt.Errorf("unknown role: %v", m.Role)
 		}
@@ -69,6 +73,87 @@ const (
 )
 
 func (c claudeInterface) Streaming(messages []Message, stream io.Writer) (*Response, error) {
+	return c.streamingOnce(messages, stream, []string{humanPrompt})
+}
+
+func (c claudeInterface) StreamingEvents(ctx context.Context, messages []Message) (<-chan StreamEvent, error) {
+	return c.streamingOnceEvents(ctx, messages, []string{humanPrompt})
+}
+
+// StreamingWithTools synthesizes Anthropic's classic XML-style <function_calls>
+// protocol on top of the claude-v2 Bedrock API, which has no native tool-calling
+// support: the available tools are described in a preamble prepended to the
+// conversation, and each turn's completion is scanned for an <invoke> block. When
+// one is found, the named tool is run and its output fed back as a human turn
+// inside a <function_results> block, looping until the model answers without
+// invoking a tool.
+func (c claudeInterface) StreamingWithTools(messages []Message, tools *ToolRegistry, stream io.Writer) (*Response, error) {
+	turns := append([]Message{{Role: RoleSystem, Content: toolsPreamble(tools)}}, messages...)
+	call := func(turns []Message) (*Response, error) {
+		return c.streamingOnce(turns, stream, []string{humanPrompt, functionCallsClose})
+	}
+	return runClaudeToolLoop(call, tools, turns)
+}
+
+// runClaudeToolLoop drives the <function_calls>/<invoke> tool-calling
+// conversation: it calls call with the running turns, parses any
+// <invoke> requests out of the completion, dispatches them against
+// tools, and feeds the results back as a function_results turn, looping
+// until a completion has no <invoke>. It's factored out of
+// StreamingWithTools so the loop can be exercised with a fake call in
+// tests.
+func runClaudeToolLoop(call func([]Message) (*Response, error), tools *ToolRegistry, turns []Message) (*Response, error) {
+	var generated []Message
+	var usage TokenCount
+	for {
+		r, err := call(turns)
+		if err != nil {
+			return nil, err
+		}
+		usage = usage.Add(r.Usage)
+		calls, ok := parseFunctionCalls(r.Content)
+		if !ok {
+			out := Response{
+				Content:      r.Content,
+				FinishReason: r.FinishReason,
+				Messages:     append(generated, Message{Role: RoleAssistant, Content: r.Content}),
+				Usage:        usage,
+			}
+			return &out, nil
+		}
+		assistantMsg := Message{Role: RoleAssistant, Content: r.Content, ToolCalls: calls}
+		generated = append(generated, assistantMsg)
+		turns = append(turns, assistantMsg)
+		for _, call := range calls {
+			result := tools.Call(context.Background(), call)
+			resultMsg := Message{
+				Role:       RoleTool,
+				Content:    toolResultText(result),
+				ToolCallID: result.ID,
+				ToolName:   result.Name,
+			}
+			generated = append(generated, resultMsg)
+			turns = append(turns, resultMsg)
+		}
+	}
+}
+
+// streamingOnce is Streaming, generalized to accept extra stop sequences so
+// StreamingWithTools can halt generation right after a </function_calls> block.
+func (c claudeInterface) streamingOnce(messages []Message, stream io.Writer, stopSequences []string) (*Response, error) {
+	events, err := c.streamingOnceEvents(context.Background(), messages, stopSequences)
+	if err != nil {
+		return nil, err
+	}
+	return collectEvents(events, stream)
+}
+
+// streamingOnceEvents is streamingOnce's underlying primitive: it drives the
+// Bedrock event stream and emits a TextDelta per completion chunk, a
+// UsageReport as soon as the terminal chunk's invocation metrics arrive, and
+// a FinishEvent reflecting the completion's stop_reason (claude-v2 reports
+// "max_tokens" when truncated, empty otherwise).
+func (c claudeInterface) streamingOnceEvents(ctx context.Context, messages []Message, stopSequences []string) (<-chan StreamEvent, error) {
 	prompt, err := claudePrompt(messages)
 	if err != nil {
 		return nil, err
@@ -79,11 +164,11 @@ func (c claudeInterface) Streaming(messages []Message, stream io.Writer) (*Respo
 		Temperature:      1,
 		TopK:             250,
 		TopP:             0.999,
-		StopSequences:    []string{humanPrompt},
+		StopSequences:    stopSequences,
 		AnthropicVersion: "bedrock-2023-05-31",
 	}
 	body, _ := json.MarshalIndent(bedrockReq, "", "  ")
-	resp, err := c.c.InvokeModelWithResponseStream(context.Background(), &bedrockruntime.InvokeModelWithResponseStreamInput{
+	resp, err := c.c.InvokeModelWithResponseStream(ctx, &bedrockruntime.InvokeModelWithResponseStreamInput{
 		Body:        body,
 		ModelId:     aws.String("anthropic.claude-v2"),
 		Accept:      aws.String("*/*"),
@@ -92,47 +177,110 @@ func (c claudeInterface) Streaming(messages []Message, stream io.Writer) (*Respo
 	if err != nil {
 		return nil, err
 	}
-	s := resp.GetStream()
-	r := s.Reader
-	defer r.Close()
-	content := new(bytes.Buffer)
-	var n int
-	const debug = false
-	first := true
-	for e := range r.Events() {
-		n++
-		if debug {
-			fmt.Printf("<event %d: %T>", n, e)
-		}
-		switch v := e.(type) {
-		case *types.ResponseStreamMemberChunk:
-			var br bedrockResponse
-			if err := json.Unmarshal(v.Value.Bytes, &br); err != nil {
-				return nil, err
-			}
-			if first {
-				// for some unknown reason, claude-v2 always returns a leading space
-				br.Completion = strings.TrimLeftFunc(br.Completion, unicode.IsSpace)
-				first = false
+	events := make(chan StreamEvent)
+	go func() {
+		defer close(events)
+		s := resp.GetStream()
+		r := s.Reader
+		defer r.Close()
+		first := true
+		var stopReason string
+		for e := range r.Events() {
+			switch v := e.(type) {
+			case *types.ResponseStreamMemberChunk:
+				var br bedrockResponse
+				if err := json.Unmarshal(v.Value.Bytes, &br); err != nil {
+					events <- StreamError{Err: err}
+					return
+				}
+				if first {
+					// for some unknown reason, claude-v2 always returns a leading space
+					br.Completion = strings.TrimLeftFunc(br.Completion, unicode.IsSpace)
+					first = false
+				}
+				if len(br.Completion) > 0 {
+					events <- TextDelta{Content: br.Completion}
+				}
+				if len(br.StopReason) > 0 {
+					stopReason = br.StopReason
+				}
+				if m := br.Metrics; m != nil {
+					// only present on the terminal chunk of the event stream
+					events <- UsageReport{Usage: TokenCount{
+						Prompt:     m.InputTokenCount,
+						Completion: m.OutputTokenCount,
+						Total:      m.InputTokenCount + m.OutputTokenCount,
+					}}
+				}
+			case *types.UnknownUnionMember:
+				events <- StreamError{Err: fmt.Errorf("unknown union member: %v", v)}
+				return
+			default:
+				events <- StreamError{Err: fmt.Errorf("union is nil or unknown type: %T %v", v, v)}
+				return
 			}
-			fmt.Fprint(stream, br.Completion)
-			content.WriteString(br.Completion)
-		case *types.UnknownUnionMember:
-			return nil, fmt.Errorf("unknown union member: %v", v)
-		default:
-			return nil, fmt.Errorf("union is nil or unknown type: %T %v", v, v)
 		}
+		if err := r.Err(); err != nil {
+			events <- StreamError{Err: err}
+			return
+		}
+		events <- FinishEvent{Reason: claudeFinishReason(stopReason)}
+	}()
+	return events, nil
+}
+
+// claudeFinishReason maps claude-v2's stop_reason onto the shared FinishReason
+// enum; stop_reason is empty for a normal completion against this API version.
+func claudeFinishReason(stopReason string) FinishReason {
+	switch stopReason {
+	case "max_tokens":
+		return FinishReasonLength
+	default:
+		return FinishReasonStop
 	}
-	if err := r.Err(); err != nil {
-		return nil, err
+}
+
+const functionCallsClose = "</function_calls>"
+
+// toolsPreamble describes the registered tools to claude-v2 in prose, instructing
+// it to request one by emitting an <function_calls> block instead of answering
+// directly. Parameters are passed as a single raw-JSON <parameters> blob (matching
+// the tool's Schema) rather than one XML tag per field, so ToolCall.Arguments stays
+// a uniform json.RawMessage across backends.
+func toolsPreamble(tools *ToolRegistry) string {
+	b := new(bytes.Buffer)
+	fmt.Fprintf(b, "you have access to the following tools. when you need one, respond with "+
+		"*only* a function_calls block (no other text), like:\n\n"+
+		"<function_calls>\n<invoke>\n<tool_name>NAME</tool_name>\n<parameters>{...json args...}</parameters>\n</invoke>\n</function_calls>\n\n"+
+		"otherwise just answer normally. the tools are:\n\n")
+	for _, t := range tools.List() {
+		schema, _ := json.Marshal(t.Schema)
+		fmt.Fprintf(b, "- %s: %s\n  parameters schema: %s\n", t.Name, t.Description, schema)
+	}
+	return b.String()
+}
+
+var invokePattern = regexp.MustCompile(`(?s)<invoke>\s*<tool_name>(.*?)</tool_name>\s*<parameters>(.*?)</parameters>\s*</invoke>`)
+
+// parseFunctionCalls scans an assistant completion for a <function_calls> block
+// and extracts each <invoke>'s tool name and raw-JSON parameters.
+func parseFunctionCalls(content string) ([]ToolCall, bool) {
+	if !strings.Contains(content, "<function_calls>") {
+		return nil, false
 	}
-	if debug {
-		fmt.Printf("<%d events done>", n)
+	matches := invokePattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil, false
 	}
-	return &Response{
-		Content:      strings.TrimSpace(content.String()),
-		FinishReason: FinishReasonStop,
-	}, nil
+	calls := make([]ToolCall, len(matches))
+	for i, m := range matches {
+		calls[i] = ToolCall{
+			ID:        fmt.Sprintf("call_%d", i),
+			Name:      strings.TrimSpace(m[1]),
+			Arguments: json.RawMessage(strings.TrimSpace(m[2])),
+		}
+	}
+	return calls, true
 }
 
 type bedrockRequest struct {
@@ -151,8 +299,16 @@ func (r bedrockRequest) String() string {
 }
 
 type bedrockResponse struct {
-	Completion string `json:"completion,omitempty"`
-	StopReason string `json:"stop_reason,omitempty"`
+	Completion string                    `json:"completion,omitempty"`
+	StopReason string                    `json:"stop_reason,omitempty"`
+	Metrics    *bedrockInvocationMetrics `json:"amazon-bedrock-invocationMetrics,omitempty"`
+}
+
+type bedrockInvocationMetrics struct {
+	InputTokenCount   int `json:"inputTokenCount"`
+	OutputTokenCount  int `json:"outputTokenCount"`
+	InvocationLatency int `json:"invocationLatency"`
+	FirstByteLatency  int `json:"firstByteLatency"`
 }
 
 func (r bedrockResponse) String() string {
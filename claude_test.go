@@ -0,0 +1,89 @@
+package chat
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseFunctionCallsExtractsInvoke(t *testing.T) {
+	content := "<function_calls>\n<invoke>\n<tool_name>get_weather</tool_name>\n" +
+		`<parameters>{"location":"boston"}</parameters>` + "\n</invoke>\n</function_calls>"
+
+	calls, ok := parseFunctionCalls(content)
+	if !ok {
+		t.Fatal("expected parseFunctionCalls to find an invoke block")
+	}
+	if len(calls) != 1 {
+		t.Fatalf("len(calls) = %d, want 1", len(calls))
+	}
+	if calls[0].Name != "get_weather" {
+		t.Fatalf("Name = %q, want get_weather", calls[0].Name)
+	}
+	if string(calls[0].Arguments) != `{"location":"boston"}` {
+		t.Fatalf("Arguments = %q", calls[0].Arguments)
+	}
+}
+
+func TestParseFunctionCallsNoInvoke(t *testing.T) {
+	if _, ok := parseFunctionCalls("the weather in boston is sunny."); ok {
+		t.Fatal("expected no invoke block to be found")
+	}
+}
+
+func TestRunClaudeToolLoopGetWeather(t *testing.T) {
+	tools := NewToolRegistry(getWeatherTool())
+	turns := []Message{{Role: RoleSystem, Content: toolsPreamble(tools)}, {Role: RoleUser, Content: "what's the weather in boston?"}}
+
+	turn := 0
+	call := func(turns []Message) (*Response, error) {
+		turn++
+		switch turn {
+		case 1:
+			return &Response{
+				Content: "<function_calls>\n<invoke>\n<tool_name>get_weather</tool_name>\n" +
+					`<parameters>{"location":"boston"}</parameters>` + "\n</invoke>\n</function_calls>",
+				Usage: TokenCount{Prompt: 10, Completion: 5, Total: 15},
+			}, nil
+		case 2:
+			return &Response{
+				Content:      "it's 72F in boston",
+				FinishReason: FinishReasonStop,
+				Usage:        TokenCount{Prompt: 20, Completion: 8, Total: 28},
+			}, nil
+		default:
+			t.Fatalf("unexpected turn %d", turn)
+			return nil, nil
+		}
+	}
+
+	resp, err := runClaudeToolLoop(call, tools, turns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.FinishReason != FinishReasonStop {
+		t.Fatalf("FinishReason = %v, want stop", resp.FinishReason)
+	}
+	if resp.Content != "it's 72F in boston" {
+		t.Fatalf("Content = %q", resp.Content)
+	}
+	if want := (TokenCount{Prompt: 30, Completion: 13, Total: 43}); resp.Usage != want {
+		t.Fatalf("Usage = %+v, want %+v", resp.Usage, want)
+	}
+
+	var toolMsg *Message
+	for i := range resp.Messages {
+		if resp.Messages[i].Role == RoleTool {
+			toolMsg = &resp.Messages[i]
+		}
+	}
+	if toolMsg == nil {
+		t.Fatal("expected a RoleTool message recording the get_weather result")
+	}
+	var out map[string]any
+	if err := json.Unmarshal([]byte(toolMsg.Content), &out); err != nil {
+		t.Fatalf("tool result isn't valid JSON: %v", err)
+	}
+	if out["location"] != "boston" {
+		t.Fatalf("tool result location = %v", out["location"])
+	}
+}
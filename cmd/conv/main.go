@@ -0,0 +1,162 @@
+// command conv drives a chat.Store: `conv new`, `reply`, `view`, `rm`,
+// `branches`, `checkout`, and `edit` let you hold a persisted, branching
+// conversation with an LLM across multiple invocations instead of a single
+// stdin session.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xoba/chat"
+	"github.com/xoba/openai"
+)
+
+func main() {
+	if err := Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func Run() error {
+	if len(os.Args) < 2 {
+		return fmt.Errorf("usage: conv {new,reply,view,rm,branches,checkout,edit} ...")
+	}
+	store, err := chat.NewStore("conv.db")
+	if err != nil {
+		return err
+	}
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "new":
+		return doNew(store, strings.Join(args, " "))
+	case "reply":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: conv reply <id> <text>")
+		}
+		return doReply(store, args[0], strings.Join(args[1:], " "))
+	case "view":
+		if len(args) < 1 {
+			return fmt.Errorf("usage: conv view <id>")
+		}
+		return doView(store, args[0])
+	case "rm":
+		if len(args) < 1 {
+			return fmt.Errorf("usage: conv rm <id>")
+		}
+		return store.Remove(args[0])
+	case "branches":
+		if len(args) < 1 {
+			return fmt.Errorf("usage: conv branches <id>")
+		}
+		return doBranches(store, args[0])
+	case "checkout":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: conv checkout <id> <branch>")
+		}
+		return store.Checkout(args[0], args[1])
+	case "edit":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: conv edit <message-id> <new text>")
+		}
+		return doEdit(store, args[0], strings.Join(args[1:], " "))
+	default:
+		return fmt.Errorf("unknown subcommand %q", cmd)
+	}
+}
+
+func doNew(store *chat.Store, title string) error {
+	c, err := store.New(title)
+	if err != nil {
+		return err
+	}
+	fmt.Println(c.ID)
+	return nil
+}
+
+func doReply(store *chat.Store, id, text string) error {
+	if _, err := store.Reply(id, text); err != nil {
+		return err
+	}
+	history, err := store.View(id)
+	if err != nil {
+		return err
+	}
+	var messages []chat.Message
+	for _, m := range history {
+		messages = append(messages, m.Message)
+	}
+	model, err := LoadModel()
+	if err != nil {
+		return err
+	}
+	config := chat.APIConfig{LLMInterface: model}
+	r, err := config.Streaming(messages, os.Stdout)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	_, err = store.Append(id, chat.Message{Role: chat.RoleAssistant, Content: r.Content}, r.Usage, fmt.Sprintf("%s", model))
+	return err
+}
+
+func doView(store *chat.Store, id string) error {
+	history, err := store.View(id)
+	if err != nil {
+		return err
+	}
+	for _, m := range history {
+		fmt.Printf("%s [%s]:\n\n%s\n\n", roleLabel(m.Role), m.ID, m.Content)
+	}
+	return nil
+}
+
+func doEdit(store *chat.Store, msgID, newContent string) error {
+	branchID, err := store.Edit(msgID, newContent)
+	if err != nil {
+		return err
+	}
+	fmt.Println(branchID)
+	return nil
+}
+
+// roleLabel renders a chat.Role for display; chat.Role has no generated
+// String() method, so %s on it would print "%!s(chat.Role=3)" instead.
+func roleLabel(r chat.Role) string {
+	switch r {
+	case chat.RoleSystem:
+		return "system"
+	case chat.RoleUser:
+		return "user"
+	case chat.RoleAssistant:
+		return "assistant"
+	case chat.RoleTool:
+		return "tool"
+	default:
+		return fmt.Sprintf("role(%d)", int(r))
+	}
+}
+
+func doBranches(store *chat.Store, id string) error {
+	branches, err := store.Branches(id)
+	if err != nil {
+		return err
+	}
+	for _, b := range branches {
+		fmt.Printf("%s\thead=%s\n", b.Name, b.Head)
+	}
+	return nil
+}
+
+func LoadModel() (chat.LLMInterface, error) {
+	buf, err := os.ReadFile("openai_key.txt")
+	if err != nil {
+		return nil, err
+	}
+	c, err := openai.NewClient(strings.TrimSpace(string(buf)))
+	if err != nil {
+		return nil, err
+	}
+	return chat.GPT4(chat.GPT4ModeTurbo, c)
+}
@@ -0,0 +1,168 @@
+// command grpc-backend re-exports an existing chat.LLMInterface over gRPC, so
+// another process can consume it via chat.GRPCBackend without linking against
+// this module's provider SDKs.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"google.golang.org/grpc"
+
+	"github.com/xoba/chat"
+	"github.com/xoba/chat/chatpb"
+)
+
+func main() {
+	if err := Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func Run() error {
+	model := flag.String("model", "", "backend to serve: claude2 (gpt4 and gpt4-turbo are recognized but return an error)")
+	addr := flag.String("addr", ":50051", "address to listen on")
+	flag.Parse()
+
+	llm, err := LoadModel(*model)
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	s := grpc.NewServer()
+	chatpb.RegisterChatBackendServer(s, chatBackend{llm: llm})
+	log.Printf("serving %s over gRPC on %s", *model, *addr)
+	return s.Serve(lis)
+}
+
+func LoadModel(model string) (chat.LLMInterface, error) {
+	switch model {
+	case "claude2":
+		c, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return chat.Claude2(bedrockruntime.NewFromConfig(c))
+	case "gpt4", "gpt4-turbo":
+		return nil, fmt.Errorf("model %q: wire up a github.com/xoba/openai client before use", model)
+	default:
+		return nil, fmt.Errorf("unknown -model %q", model)
+	}
+}
+
+// chatBackend adapts a chat.LLMInterface to the chatpb.ChatBackendServer
+// interface generated from chat.proto.
+type chatBackend struct {
+	chatpb.UnimplementedChatBackendServer
+	llm chat.LLMInterface
+}
+
+func (b chatBackend) Predict(ctx context.Context, req *chatpb.PredictRequest) (*chatpb.PredictResponse, error) {
+	var buf bytes.Buffer
+	resp, err := b.llm.Streaming(chatMessages(req.Messages), &buf)
+	if err != nil {
+		return nil, err
+	}
+	return &chatpb.PredictResponse{
+		Content:      resp.Content,
+		FinishReason: finishReasonToProto(resp.FinishReason),
+		Usage:        tokenCountToProto(resp.Usage),
+	}, nil
+}
+
+func (b chatBackend) PredictStream(req *chatpb.PredictRequest, stream chatpb.ChatBackend_PredictStreamServer) error {
+	events, err := b.llm.StreamingEvents(stream.Context(), chatMessages(req.Messages))
+	if err != nil {
+		return err
+	}
+	var usage chat.TokenCount
+	finish := chat.FinishReasonUnknown
+	for e := range events {
+		switch v := e.(type) {
+		case chat.TextDelta:
+			if err := stream.Send(&chatpb.PredictChunk{ContentDelta: v.Content}); err != nil {
+				return err
+			}
+		case chat.UsageReport:
+			usage = v.Usage
+		case chat.FinishEvent:
+			finish = v.Reason
+		case chat.StreamError:
+			return v.Err
+		}
+	}
+	return stream.Send(&chatpb.PredictChunk{
+		Done:         true,
+		FinishReason: finishReasonToProto(finish),
+		Usage:        tokenCountToProto(usage),
+	})
+}
+
+func (b chatBackend) TokenEstimate(ctx context.Context, req *chatpb.PredictRequest) (*chatpb.TokenCount, error) {
+	tc, err := b.llm.EstimateTokens(chatMessages(req.Messages))
+	if err != nil {
+		return nil, err
+	}
+	return tokenCountToProto(tc), nil
+}
+
+func (b chatBackend) Info(ctx context.Context, req *chatpb.InfoRequest) (*chatpb.InfoResponse, error) {
+	return &chatpb.InfoResponse{
+		MaxTokens: int32(b.llm.MaxTokens()),
+		Model:     fmt.Sprintf("%s", b.llm),
+	}, nil
+}
+
+func chatMessages(messages []*chatpb.Message) []chat.Message {
+	out := make([]chat.Message, len(messages))
+	for i, m := range messages {
+		out[i] = chat.Message{Role: roleFromProto(m.Role), Content: m.Content}
+	}
+	return out
+}
+
+func roleFromProto(r chatpb.Role) chat.Role {
+	switch r {
+	case chatpb.Role_ROLE_SYSTEM:
+		return chat.RoleSystem
+	case chatpb.Role_ROLE_USER:
+		return chat.RoleUser
+	case chatpb.Role_ROLE_ASSISTANT:
+		return chat.RoleAssistant
+	case chatpb.Role_ROLE_TOOL:
+		return chat.RoleTool
+	default:
+		return chat.RoleUser
+	}
+}
+
+func finishReasonToProto(r chat.FinishReason) chatpb.FinishReason {
+	switch r {
+	case chat.FinishReasonStop:
+		return chatpb.FinishReason_FINISH_REASON_STOP
+	case chat.FinishReasonLength:
+		return chatpb.FinishReason_FINISH_REASON_LENGTH
+	default:
+		return chatpb.FinishReason_FINISH_REASON_UNKNOWN
+	}
+}
+
+func tokenCountToProto(tc chat.TokenCount) *chatpb.TokenCount {
+	return &chatpb.TokenCount{
+		Prompt:     int32(tc.Prompt),
+		Completion: int32(tc.Completion),
+		Total:      int32(tc.Total),
+		Estimated:  tc.Estimated,
+	}
+}
@@ -0,0 +1,93 @@
+package chat
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// StreamEvent is one piece of a streaming exchange with an LLM: assistant
+// text, a chunk of tool-call arguments, usage metadata, or the terminal
+// finish/error signal. Lets downstream code (a TUI, an HTTP SSE proxy) tell
+// "assistant text" apart from "tool call arguments" from "usage metadata".
+type StreamEvent interface {
+	isStreamEvent()
+}
+
+// TextDelta is a chunk of assistant-visible text.
+type TextDelta struct {
+	Content string
+}
+
+// ToolCallDelta is a chunk of a tool call being assembled; ID and Name are
+// only populated on the delta(s) that introduce a new call, matching how the
+// underlying provider APIs stream them.
+type ToolCallDelta struct {
+	ID             string
+	Name           string
+	ArgumentsDelta string
+}
+
+// UsageReport carries token usage metadata as soon as the backend has it
+// (often only on the terminal chunk).
+type UsageReport struct {
+	Usage TokenCount
+}
+
+// FinishEvent is the terminal, successful event of a stream.
+type FinishEvent struct {
+	Reason FinishReason
+}
+
+// StreamError is the terminal event of a stream that ended in error; no
+// further events follow it on the channel.
+type StreamError struct {
+	Err error
+}
+
+func (TextDelta) isStreamEvent()     {}
+func (ToolCallDelta) isStreamEvent() {}
+func (UsageReport) isStreamEvent()   {}
+func (FinishEvent) isStreamEvent()   {}
+func (StreamError) isStreamEvent()   {}
+
+// collectEvents drains events, forwarding TextDelta content to w, and
+// assembles the equivalent *Response — this is the back-compat path that lets
+// Streaming be implemented on top of StreamingEvents. Plain Streaming never
+// invokes tools, so a ToolCallDelta (reserved for a future StreamingEvents
+// analogue of StreamingWithTools) is otherwise unexpected here and ignored.
+func collectEvents(events <-chan StreamEvent, w io.Writer) (*Response, error) {
+	var content strings.Builder
+	var usage TokenCount
+	finish := FinishReasonUnknown
+	for e := range events {
+		switch v := e.(type) {
+		case TextDelta:
+			fmt.Fprint(w, v.Content)
+			content.WriteString(v.Content)
+		case UsageReport:
+			usage = v.Usage
+		case FinishEvent:
+			finish = v.Reason
+		case StreamError:
+			return nil, v.Err
+		}
+	}
+	return &Response{
+		Content:      content.String(),
+		FinishReason: finish,
+		Usage:        usage,
+	}, nil
+}
+
+// eventWriter adapts a StreamEvent channel to io.Writer, so existing
+// transport code that streams text into an io.Writer can feed a
+// StreamingEvents implementation unchanged.
+type eventWriter struct {
+	events chan<- StreamEvent
+}
+
+func (w eventWriter) Write(p []byte) (int, error) {
+	w.events <- TextDelta{Content: string(p)}
+	return len(p), nil
+}
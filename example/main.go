@@ -36,7 +36,7 @@ func Run() error {
 	if err != nil {
 		return err
 	}
-	if _, err := chat.Streaming(config, exampleFile); err != nil {
+	if err := chat.Streaming(config, exampleFile); err != nil {
 		return err
 	}
 	return nil
@@ -63,7 +63,27 @@ func LoadModel() (chat.LLMInterface, error) {
 	if err != nil {
 		return nil, err
 	}
-	return chat.NewMultiLLMInterface(llm1, llm2)
+	llm3, err := NewGemini()
+	if err != nil {
+		return nil, err
+	}
+	smaller, err := chat.NewMultiInterface(llm3, llm2)
+	if err != nil {
+		return nil, err
+	}
+	return chat.NewMultiInterface(smaller, llm1)
+}
+
+func NewGemini() (chat.LLMInterface, error) {
+	buf, err := os.ReadFile("gemini_key.txt")
+	if err != nil {
+		return nil, err
+	}
+	c, err := chat.NewGeminiClient(string(buf))
+	if err != nil {
+		return nil, err
+	}
+	return chat.Gemini("gemini-pro", c)
 }
 
 func NewBedrockRuntime() (*bedrockruntime.Client, error) {
@@ -0,0 +1,210 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GeminiClient talks to Google's generative language REST API (v1beta).
+type GeminiClient struct {
+	apiKey string
+	client *http.Client
+}
+
+func NewGeminiClient(apiKey string) (*GeminiClient, error) {
+	return &GeminiClient{apiKey: strings.TrimSpace(apiKey), client: http.DefaultClient}, nil
+}
+
+const geminiBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+func Gemini(model string, c *GeminiClient) (LLMInterface, error) {
+	return geminiInterface{model: model, c: c}, nil
+}
+
+type geminiInterface struct {
+	model string
+	c     *GeminiClient
+}
+
+func (i geminiInterface) String() string {
+	return fmt.Sprintf("google.%s", i.model)
+}
+
+func (geminiInterface) MaxTokens() int {
+	return 30720
+}
+
+// EstimateTokens calls Gemini's countTokens endpoint for an accurate count,
+// analogous to how gpt4interface uses tiktoken for precision.
+func (i geminiInterface) EstimateTokens(messages []Message) (TokenCount, error) {
+	system, contents, err := geminiContents(messages)
+	if err != nil {
+		return TokenCount{}, err
+	}
+	req := geminiRequest{
+		SystemInstruction: system,
+		Contents:          contents,
+	}
+	var resp struct {
+		TotalTokens int `json:"totalTokens"`
+	}
+	if err := i.c.call(context.Background(), i.model, "countTokens", req, &resp); err != nil {
+		return TokenCount{}, err
+	}
+	return TokenCount{Prompt: resp.TotalTokens, Total: resp.TotalTokens, Estimated: false}, nil
+}
+
+func geminiContents(messages []Message) (*geminiContent, []geminiContent, error) {
+	var system *geminiContent
+	var contents []geminiContent
+	for _, m := range messages {
+		switch m.Role {
+		case RoleSystem:
+			if system == nil {
+				system = &geminiContent{Parts: []geminiPart{{Text: m.Content}}}
+			} else {
+				system.Parts = append(system.Parts, geminiPart{Text: m.Content})
+			}
+		case RoleUser:
+			contents = append(contents, geminiContent{Role: "user", Parts: []geminiPart{{Text: m.Content}}})
+		case RoleAssistant:
+			contents = append(contents, geminiContent{Role: "model", Parts: []geminiPart{{Text: m.Content}}})
+		default:
+			return nil, nil, fmt.Errorf("unknown role: %v", m.Role)
+		}
+	}
+	return system, contents, nil
+}
+
+func (i geminiInterface) Streaming(messages []Message, stream io.Writer) (*Response, error) {
+	events, err := i.StreamingEvents(context.Background(), messages)
+	if err != nil {
+		return nil, err
+	}
+	return collectEvents(events, stream)
+}
+
+func (i geminiInterface) StreamingEvents(ctx context.Context, messages []Message) (<-chan StreamEvent, error) {
+	system, contents, err := geminiContents(messages)
+	if err != nil {
+		return nil, err
+	}
+	req := geminiRequest{
+		SystemInstruction: system,
+		Contents:          contents,
+	}
+	body, err := i.c.open(ctx, i.model, "streamGenerateContent", req)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan StreamEvent)
+	go func() {
+		defer close(events)
+		defer body.Close()
+		var finish FinishReason
+		dec := json.NewDecoder(body)
+		// the endpoint streams a single top-level JSON array, one candidate chunk at
+		// a time, rather than newline-delimited objects
+		if _, err := dec.Token(); err != nil {
+			events <- StreamError{Err: fmt.Errorf("can't read opening array token: %w", err)}
+			return
+		}
+		for dec.More() {
+			var chunk geminiStreamChunk
+			if err := dec.Decode(&chunk); err != nil {
+				events <- StreamError{Err: err}
+				return
+			}
+			if len(chunk.Candidates) == 0 {
+				continue
+			}
+			c := chunk.Candidates[0]
+			if len(c.Content.Parts) > 0 {
+				events <- TextDelta{Content: c.Content.Parts[0].Text}
+			}
+			if len(c.FinishReason) > 0 {
+				switch c.FinishReason {
+				case "STOP":
+					finish = FinishReasonStop
+				case "MAX_TOKENS":
+					finish = FinishReasonLength
+				default: // e.g. "SAFETY"
+					finish = FinishReasonUnknown
+				}
+			}
+		}
+		if finish == 0 {
+			finish = FinishReasonStop
+		}
+		events <- FinishEvent{Reason: finish}
+	}()
+	return events, nil
+}
+
+func (geminiInterface) StreamingWithTools(messages []Message, tools *ToolRegistry, stream io.Writer) (*Response, error) {
+	return nil, fmt.Errorf("gemini: tool calling not yet implemented")
+}
+
+type geminiRequest struct {
+	SystemInstruction *geminiContent  `json:"systemInstruction,omitempty"`
+	Contents          []geminiContent `json:"contents"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiStreamChunk struct {
+	Candidates []geminiCandidate `json:"candidates"`
+}
+
+type geminiCandidate struct {
+	Content      geminiContent `json:"content"`
+	FinishReason string        `json:"finishReason,omitempty"`
+}
+
+// call performs a non-streaming JSON request against the given method of model,
+// decoding the response into out.
+func (c *GeminiClient) call(ctx context.Context, model, method string, body, out any) error {
+	rc, err := c.open(ctx, model, method, body)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return json.NewDecoder(rc).Decode(out)
+}
+
+// open issues the request and returns the raw response body for the caller to
+// read (streamed or otherwise) and close.
+func (c *GeminiClient) open(ctx context.Context, model, method string, body any) (io.ReadCloser, error) {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/models/%s:%s?key=%s", geminiBaseURL, model, method, c.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("gemini %s %s: %s: %s", method, model, resp.Status, msg)
+	}
+	return resp.Body, nil
+}
@@ -3,6 +3,7 @@ package chat
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -42,12 +43,12 @@ func (gpt4interface) MaxTokens() int {
 }
 
 // seems to be a precise estimate
-func (i gpt4interface) TokenEstimate(messages []Message) (int, error) {
+func (i gpt4interface) EstimateTokens(messages []Message) (TokenCount, error) {
 	n, err := numTokensFromMessages(messages, "gpt-4")
 	if err != nil {
-		return 0, err
+		return TokenCount{}, err
 	}
-	return n, nil
+	return TokenCount{Prompt: n, Total: n, Estimated: false}, nil
 }
 
 func numTokensFromMessages(messages []Message, model string) (int, error) {
@@ -89,6 +90,8 @@ func numTokensFromMessages(messages []Message, model string) (int, error) {
 			role = "user"
 		case RoleAssistant:
 			role = "assistant"
+		case RoleTool:
+			role = "tool"
 		default:
 			return 0, fmt.Errorf("unknown role: %v", message.Role)
 		}
@@ -99,7 +102,53 @@ func numTokensFromMessages(messages []Message, model string) (int, error) {
 	return numTokens, nil
 }
 
+// openaiMessages converts the backend-agnostic Message slice into the
+// openai package's wire format, carrying ToolCalls/ToolCallID/ToolName
+// through for RoleAssistant/RoleTool turns.
+func openaiMessages(messages []Message) ([]openai.ChatCompletionMessage, error) {
+	out := make([]openai.ChatCompletionMessage, len(messages))
+	for i, m := range messages {
+		var role string
+		switch m.Role {
+		case RoleSystem:
+			role = "system"
+		case RoleUser:
+			role = "user"
+		case RoleAssistant:
+			role = "assistant"
+		case RoleTool:
+			role = "tool"
+		default:
+			return nil, fmt.Errorf("unknown role: %v", m.Role)
+		}
+		var toolCalls []openai.ToolCall
+		for _, tc := range m.ToolCalls {
+			toolCalls = append(toolCalls, openai.ToolCall{
+				ID:       tc.ID,
+				Type:     openai.ToolTypeFunction,
+				Function: openai.FunctionCall{Name: tc.Name, Arguments: string(tc.Arguments)},
+			})
+		}
+		out[i] = openai.ChatCompletionMessage{
+			Role:       role,
+			Content:    m.Content,
+			ToolCalls:  toolCalls,
+			ToolCallID: m.ToolCallID,
+			Name:       m.ToolName,
+		}
+	}
+	return out, nil
+}
+
 func (i gpt4interface) Streaming(messages []Message, stream io.Writer) (*Response, error) {
+	events, err := i.StreamingEvents(context.Background(), messages)
+	if err != nil {
+		return nil, err
+	}
+	return collectEvents(events, stream)
+}
+
+func (i gpt4interface) StreamingEvents(ctx context.Context, messages []Message) (<-chan StreamEvent, error) {
 	list, err := openaiMessages(messages)
 	if err != nil {
 		return nil, err
@@ -111,31 +160,35 @@ func (i gpt4interface) Streaming(messages []Message, stream io.Writer) (*Respons
 	case GPT4ModeTurbo:
 		m = "gpt-4-1106-preview"
 	}
-	r, err := complete(i.c, m, 0, stream, list...)
-	if err != nil {
-		return nil, err
-	}
-	out := Response{
-		Content: r.Content,
-	}
-	switch r.FinishReason {
-	case "stop":
-		out.FinishReason = FinishReasonStop
-	case "length":
-		out.FinishReason = FinishReasonLength
-	default:
-		out.FinishReason = FinishReasonUnknown
-	}
-	return &out, nil
+	events := make(chan StreamEvent)
+	go func() {
+		defer close(events)
+		r, err := complete(i.c, m, 0, eventWriter{events}, list...)
+		if err != nil {
+			events <- StreamError{Err: err}
+			return
+		}
+		events <- UsageReport{Usage: r.Usage}
+		switch r.FinishReason {
+		case "stop":
+			events <- FinishEvent{Reason: FinishReasonStop}
+		case "length":
+			events <- FinishEvent{Reason: FinishReasonLength}
+		default:
+			events <- FinishEvent{Reason: FinishReasonUnknown}
+		}
+	}()
+	return events, nil
 }
 
-func complete(c client, model string, maxTokens int, stream io.Writer, messages ...openai.ChatCompletionMessage) (*completionResponse, error) {
+func complete(c *openai.Client, model string, maxTokens int, stream io.Writer, messages ...openai.ChatCompletionMessage) (*completionResponse, error) {
 	resp, err := c.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
-		Model:       model,
-		Messages:    messages,
-		MaxTokens:   maxTokens,
-		Temperature: 0.7,
-		TopP:        1,
+		Model:         model,
+		Messages:      messages,
+		MaxTokens:     maxTokens,
+		Temperature:   0.7,
+		TopP:          1,
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
 	})
 	if err != nil {
 		return nil, err
@@ -144,6 +197,7 @@ func complete(c client, model string, maxTokens int, stream io.Writer, messages
 	q := new(bytes.Buffer)
 	out := io.MultiWriter(stream, q)
 	var finishReason string
+	var usage TokenCount
 	for {
 		t, err := resp.Recv()
 		if err == io.EOF {
@@ -166,6 +220,16 @@ func complete(c client, model string, maxTokens int, stream io.Writer, messages
 			}
 			return nil, err
 		}
+		if t.Usage != nil {
+			// the final chunk requested via stream_options.include_usage carries
+			// real usage and an empty Choices slice
+			usage = TokenCount{
+				Prompt:     t.Usage.PromptTokens,
+				Completion: t.Usage.CompletionTokens,
+				Total:      t.Usage.TotalTokens,
+			}
+			continue
+		}
 		choices := t.Choices
 		if len(choices) == 0 {
 			return nil, fmt.Errorf("no choices")
@@ -178,10 +242,241 @@ func complete(c client, model string, maxTokens int, stream io.Writer, messages
 	return &completionResponse{
 		FinishReason: finishReason,
 		Content:      q.String(),
+		Usage:        usage,
 	}, nil
 }
 
 type completionResponse struct {
 	FinishReason string
 	Content      string
+	ToolCalls    []openai.ToolCall
+	Usage        TokenCount
+}
+
+func (i gpt4interface) StreamingWithTools(messages []Message, tools *ToolRegistry, stream io.Writer) (*Response, error) {
+	list, err := openaiMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+	var m string
+	switch i.m {
+	case GPT4ModeDefault:
+		m = "gpt-4"
+	case GPT4ModeTurbo:
+		m = "gpt-4-1106-preview"
+	}
+	oaiTools := openaiTools(tools)
+	call := func(list []openai.ChatCompletionMessage) (*completionResponse, error) {
+		return completeWithTools(i.c, m, 0, stream, oaiTools, list...)
+	}
+	return runToolLoop(call, tools, list)
+}
+
+// runToolLoop drives the OpenAI tool-calling conversation: it calls call
+// with the running message list, dispatches any requested tool calls
+// against tools, feeds the results back, and repeats until the model
+// stops requesting tools. It's factored out of StreamingWithTools so the
+// loop can be exercised with a fake call in tests.
+func runToolLoop(call func([]openai.ChatCompletionMessage) (*completionResponse, error), tools *ToolRegistry, list []openai.ChatCompletionMessage) (*Response, error) {
+	var generated []Message
+	var usage TokenCount
+	for {
+		r, err := call(list)
+		if err != nil {
+			return nil, err
+		}
+		usage = usage.Add(r.Usage)
+		if r.FinishReason != "tool_calls" {
+			out := Response{
+				Content:  r.Content,
+				Messages: generated,
+				Usage:    usage,
+			}
+			switch r.FinishReason {
+			case "stop":
+				out.FinishReason = FinishReasonStop
+				out.Messages = append(out.Messages, Message{Role: RoleAssistant, Content: r.Content})
+			case "length":
+				out.FinishReason = FinishReasonLength
+				out.Messages = append(out.Messages, Message{Role: RoleAssistant, Content: r.Content})
+			default:
+				out.FinishReason = FinishReasonUnknown
+			}
+			return &out, nil
+		}
+		calls := make([]ToolCall, len(r.ToolCalls))
+		for i, tc := range r.ToolCalls {
+			calls[i] = ToolCall{ID: tc.ID, Name: tc.Function.Name, Arguments: json.RawMessage(tc.Function.Arguments)}
+		}
+		assistantMsg := Message{Role: RoleAssistant, Content: r.Content, ToolCalls: calls}
+		generated = append(generated, assistantMsg)
+		list = append(list, openai.ChatCompletionMessage{
+			Role:      "assistant",
+			Content:   r.Content,
+			ToolCalls: r.ToolCalls,
+		})
+		for _, call := range calls {
+			result := tools.Call(context.Background(), call)
+			resultMsg := Message{
+				Role:       RoleTool,
+				Content:    toolResultText(result),
+				ToolCallID: result.ID,
+				ToolName:   result.Name,
+			}
+			generated = append(generated, resultMsg)
+			list = append(list, openai.ChatCompletionMessage{
+				Role:       "tool",
+				Content:    resultMsg.Content,
+				ToolCallID: result.ID,
+				Name:       result.Name,
+			})
+		}
+	}
+}
+
+func toolResultText(r ToolResult) string {
+	if len(r.Error) > 0 {
+		return fmt.Sprintf("error: %s", r.Error)
+	}
+	return string(r.Content)
+}
+
+func openaiTools(tools *ToolRegistry) []openai.Tool {
+	var out []openai.Tool
+	for _, t := range tools.List() {
+		out = append(out, openai.Tool{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.Schema,
+			},
+		})
+	}
+	return out
+}
+
+type callBuilder struct {
+	id, name string
+	args     bytes.Buffer
+}
+
+// toolCallAccumulator assembles the per-token openai.ToolCall deltas that
+// arrive over a streaming completion into complete tool calls. OpenAI
+// streams each call's id/name/arguments in pieces, indexed by Delta.ToolCalls[].Index,
+// so the fragments for a given call must be collected before it's usable.
+type toolCallAccumulator struct {
+	order    []int
+	builders map[int]*callBuilder
+}
+
+func newToolCallAccumulator() *toolCallAccumulator {
+	return &toolCallAccumulator{builders: make(map[int]*callBuilder)}
+}
+
+func (a *toolCallAccumulator) add(tc openai.ToolCall) {
+	idx := 0
+	if tc.Index != nil {
+		idx = *tc.Index
+	}
+	b, ok := a.builders[idx]
+	if !ok {
+		b = &callBuilder{}
+		a.builders[idx] = b
+		a.order = append(a.order, idx)
+	}
+	if len(tc.ID) > 0 {
+		b.id = tc.ID
+	}
+	if len(tc.Function.Name) > 0 {
+		b.name = tc.Function.Name
+	}
+	b.args.WriteString(tc.Function.Arguments)
+}
+
+func (a *toolCallAccumulator) finish() []openai.ToolCall {
+	var calls []openai.ToolCall
+	for _, idx := range a.order {
+		b := a.builders[idx]
+		calls = append(calls, openai.ToolCall{
+			ID:   b.id,
+			Type: openai.ToolTypeFunction,
+			Function: openai.FunctionCall{
+				Name:      b.name,
+				Arguments: b.args.String(),
+			},
+		})
+	}
+	return calls
+}
+
+func completeWithTools(c *openai.Client, model string, maxTokens int, stream io.Writer, tools []openai.Tool, messages ...openai.ChatCompletionMessage) (*completionResponse, error) {
+	resp, err := c.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:         model,
+		Messages:      messages,
+		MaxTokens:     maxTokens,
+		Temperature:   0.7,
+		TopP:          1,
+		Tools:         tools,
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	q := new(bytes.Buffer)
+	out := io.MultiWriter(stream, q)
+	var finishReason string
+	var usage TokenCount
+	calls := newToolCallAccumulator()
+	for {
+		t, err := resp.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			var apiError *openai.APIError
+			if errors.As(err, &apiError) {
+				p := regexp.MustCompile(`(\d+) tokens`)
+				if p.MatchString(apiError.Message) {
+					m := p.FindStringSubmatch(apiError.Message)
+					tokens, convError := strconv.ParseUint(m[1], 10, 64)
+					if convError != nil {
+						return nil, convError
+					}
+					fmt.Printf("total tokens = %d; error = %v\n", tokens, err)
+					return nil, err
+				} else {
+					return nil, err
+				}
+			}
+			return nil, err
+		}
+		if t.Usage != nil {
+			// the final chunk requested via stream_options.include_usage carries
+			// real usage and an empty Choices slice
+			usage = TokenCount{
+				Prompt:     t.Usage.PromptTokens,
+				Completion: t.Usage.CompletionTokens,
+				Total:      t.Usage.TotalTokens,
+			}
+			continue
+		}
+		choices := t.Choices
+		if len(choices) == 0 {
+			return nil, fmt.Errorf("no choices")
+		}
+		firstChoice := choices[0]
+		finishReason = string(firstChoice.FinishReason)
+		fmt.Fprint(out, firstChoice.Delta.Content)
+		for _, tc := range firstChoice.Delta.ToolCalls {
+			calls.add(tc)
+		}
+	}
+	fmt.Fprintln(out)
+	return &completionResponse{
+		FinishReason: finishReason,
+		Content:      q.String(),
+		ToolCalls:    calls.finish(),
+		Usage:        usage,
+	}, nil
 }
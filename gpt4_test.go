@@ -0,0 +1,93 @@
+package chat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/xoba/openai"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestToolCallAccumulatorAssemblesStreamedDeltas(t *testing.T) {
+	a := newToolCallAccumulator()
+	a.add(openai.ToolCall{Index: intPtr(0), ID: "call_1", Function: openai.FunctionCall{Name: "get_weather"}})
+	a.add(openai.ToolCall{Index: intPtr(0), Function: openai.FunctionCall{Arguments: `{"location":`}})
+	a.add(openai.ToolCall{Index: intPtr(0), Function: openai.FunctionCall{Arguments: `"boston"}`}})
+
+	calls := a.finish()
+	if len(calls) != 1 {
+		t.Fatalf("len(calls) = %d, want 1", len(calls))
+	}
+	if calls[0].ID != "call_1" || calls[0].Function.Name != "get_weather" {
+		t.Fatalf("unexpected call: %+v", calls[0])
+	}
+	if calls[0].Function.Arguments != `{"location":"boston"}` {
+		t.Fatalf("arguments = %q", calls[0].Function.Arguments)
+	}
+}
+
+func TestRunToolLoopGetWeather(t *testing.T) {
+	tools := NewToolRegistry(getWeatherTool())
+	list, err := openaiMessages([]Message{{Role: RoleUser, Content: "what's the weather in boston?"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	turn := 0
+	call := func(list []openai.ChatCompletionMessage) (*completionResponse, error) {
+		turn++
+		switch turn {
+		case 1:
+			return &completionResponse{
+				FinishReason: "tool_calls",
+				ToolCalls: []openai.ToolCall{{
+					ID:       "call_1",
+					Type:     openai.ToolTypeFunction,
+					Function: openai.FunctionCall{Name: "get_weather", Arguments: `{"location":"boston"}`},
+				}},
+				Usage: TokenCount{Prompt: 10, Completion: 5, Total: 15},
+			}, nil
+		case 2:
+			return &completionResponse{
+				FinishReason: "stop",
+				Content:      "it's 72F in boston",
+				Usage:        TokenCount{Prompt: 20, Completion: 8, Total: 28},
+			}, nil
+		default:
+			t.Fatalf("unexpected turn %d", turn)
+			return nil, nil
+		}
+	}
+
+	resp, err := runToolLoop(call, tools, list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.FinishReason != FinishReasonStop {
+		t.Fatalf("FinishReason = %v, want stop", resp.FinishReason)
+	}
+	if resp.Content != "it's 72F in boston" {
+		t.Fatalf("Content = %q", resp.Content)
+	}
+	if want := (TokenCount{Prompt: 30, Completion: 13, Total: 43}); resp.Usage != want {
+		t.Fatalf("Usage = %+v, want %+v", resp.Usage, want)
+	}
+
+	var toolMsg *Message
+	for i := range resp.Messages {
+		if resp.Messages[i].Role == RoleTool {
+			toolMsg = &resp.Messages[i]
+		}
+	}
+	if toolMsg == nil {
+		t.Fatal("expected a RoleTool message recording the get_weather result")
+	}
+	var out map[string]any
+	if err := json.Unmarshal([]byte(toolMsg.Content), &out); err != nil {
+		t.Fatalf("tool result isn't valid JSON: %v", err)
+	}
+	if out["location"] != "boston" {
+		t.Fatalf("tool result location = %v", out["location"])
+	}
+}
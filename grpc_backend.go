@@ -0,0 +1,143 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/xoba/chat/chatpb"
+)
+
+//go:generate protoc --go_out=. --go-grpc_out=. chat.proto
+
+// GRPCBackend dials addr and returns an LLMInterface backed by whatever
+// process is listening there and implementing the ChatBackend service
+// described in chat.proto — a llama.cpp server, vLLM, an Ollama wrapper, or
+// cmd/grpc-backend fronting another LLMInterface. Info is called once, up
+// front, so MaxTokens can be answered without a round trip on every call.
+func GRPCBackend(addr string) (LLMInterface, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("can't dial %s: %w", addr, err)
+	}
+	c := chatpb.NewChatBackendClient(conn)
+	info, err := c.Info(context.Background(), &chatpb.InfoRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("can't get info from %s: %w", addr, err)
+	}
+	return grpcInterface{addr: addr, conn: conn, c: c, info: info}, nil
+}
+
+type grpcInterface struct {
+	addr string
+	conn *grpc.ClientConn
+	c    chatpb.ChatBackendClient
+	info *chatpb.InfoResponse
+}
+
+func (i grpcInterface) String() string {
+	return fmt.Sprintf("grpc.%s(%s)", i.info.Model, i.addr)
+}
+
+func (i grpcInterface) MaxTokens() int {
+	return int(i.info.MaxTokens)
+}
+
+func (i grpcInterface) EstimateTokens(messages []Message) (TokenCount, error) {
+	tc, err := i.c.TokenEstimate(context.Background(), &chatpb.PredictRequest{Messages: grpcMessages(messages)})
+	if err != nil {
+		return TokenCount{}, err
+	}
+	return tokenCountFromProto(tc), nil
+}
+
+func (i grpcInterface) Streaming(messages []Message, stream io.Writer) (*Response, error) {
+	events, err := i.StreamingEvents(context.Background(), messages)
+	if err != nil {
+		return nil, err
+	}
+	return collectEvents(events, stream)
+}
+
+func (i grpcInterface) StreamingEvents(ctx context.Context, messages []Message) (<-chan StreamEvent, error) {
+	stream, err := i.c.PredictStream(ctx, &chatpb.PredictRequest{Messages: grpcMessages(messages)})
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan StreamEvent)
+	go func() {
+		defer close(events)
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				return
+			} else if err != nil {
+				events <- StreamError{Err: err}
+				return
+			}
+			if len(chunk.ContentDelta) > 0 {
+				events <- TextDelta{Content: chunk.ContentDelta}
+			}
+			if !chunk.Done {
+				continue
+			}
+			if chunk.Usage != nil {
+				events <- UsageReport{Usage: tokenCountFromProto(chunk.Usage)}
+			}
+			events <- FinishEvent{Reason: finishReasonFromProto(chunk.FinishReason)}
+			return
+		}
+	}()
+	return events, nil
+}
+
+func (grpcInterface) StreamingWithTools(messages []Message, tools *ToolRegistry, stream io.Writer) (*Response, error) {
+	return nil, fmt.Errorf("grpc backend: tool calling not supported")
+}
+
+func grpcMessages(messages []Message) []*chatpb.Message {
+	out := make([]*chatpb.Message, len(messages))
+	for i, m := range messages {
+		out[i] = &chatpb.Message{Role: roleToProto(m.Role), Content: m.Content}
+	}
+	return out
+}
+
+func roleToProto(r Role) chatpb.Role {
+	switch r {
+	case RoleSystem:
+		return chatpb.Role_ROLE_SYSTEM
+	case RoleUser:
+		return chatpb.Role_ROLE_USER
+	case RoleAssistant:
+		return chatpb.Role_ROLE_ASSISTANT
+	case RoleTool:
+		return chatpb.Role_ROLE_TOOL
+	default:
+		return chatpb.Role_ROLE_UNSPECIFIED
+	}
+}
+
+func finishReasonFromProto(r chatpb.FinishReason) FinishReason {
+	switch r {
+	case chatpb.FinishReason_FINISH_REASON_STOP:
+		return FinishReasonStop
+	case chatpb.FinishReason_FINISH_REASON_LENGTH:
+		return FinishReasonLength
+	default:
+		return FinishReasonUnknown
+	}
+}
+
+func tokenCountFromProto(tc *chatpb.TokenCount) TokenCount {
+	return TokenCount{
+		Prompt:     int(tc.Prompt),
+		Completion: int(tc.Completion),
+		Total:      int(tc.Total),
+		Estimated:  tc.Estimated,
+	}
+}
@@ -0,0 +1,404 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:generate stringer -type=RouterPolicyMode
+type RouterPolicyMode int
+
+const (
+	_ RouterPolicyMode = iota
+	// PriorityOrder always prefers earlier backends, falling back to later ones
+	// only when an earlier one is unhealthy or over its token budget.
+	PriorityOrder
+	// LatencyWeighted prefers whichever healthy, in-budget backend currently has
+	// the lowest observed p50 latency.
+	LatencyWeighted
+	// RoundRobin cycles through healthy, in-budget backends in turn.
+	RoundRobin
+)
+
+// RouterPolicy selects which backend a Router tries first for a given call.
+type RouterPolicy struct {
+	Mode RouterPolicyMode
+
+	// FallbackOverBudget makes candidates() fall back to the healthy backend
+	// with the largest token budget when every backend is over budget, instead
+	// of erroring out. Preserves NewMultiInterface's old best-effort behavior.
+	// Defaults to off: most Routers should surface "no backend fits" as an
+	// error rather than risk a mid-generation context-length failure.
+	FallbackOverBudget bool
+}
+
+// Router picks among N LLMInterface backends, tracking a rolling health signal
+// per backend so it can fail over transparently on transient errors (5xx,
+// Bedrock throttling, context-length errors) and back off the failing one
+// instead of hammering it on the next call.
+type Router struct {
+	backends []*routedBackend
+	policy   RouterPolicy
+
+	mu       sync.Mutex
+	rrCursor int
+}
+
+// NewRouter builds a Router over the given backends, tried in the order given
+// (subject to policy) as long as they're healthy and within token budget.
+func NewRouter(policy RouterPolicy, backends ...LLMInterface) (*Router, error) {
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("router needs at least one backend")
+	}
+	r := &Router{policy: policy}
+	for _, b := range backends {
+		r.backends = append(r.backends, &routedBackend{llm: b})
+	}
+	return r, nil
+}
+
+// uses the first smaller capacity one, until tokens exceed its limit, then uses the second one
+//
+// Matches the pre-Router multiInterface's best-effort behavior: if messages
+// are too big even for secondLarger, it's still tried (FallbackOverBudget),
+// since secondLarger remains the best available option.
+func NewMultiInterface(firstSmaller, secondLarger LLMInterface) (LLMInterface, error) {
+	if firstSmaller.MaxTokens() >= secondLarger.MaxTokens() {
+		return nil, fmt.Errorf("first interface should have less capacity than second")
+	}
+	return NewRouter(RouterPolicy{Mode: PriorityOrder, FallbackOverBudget: true}, firstSmaller, secondLarger)
+}
+
+func (r *Router) String() string {
+	names := make([]string, len(r.backends))
+	for i, b := range r.backends {
+		names[i] = fmt.Sprintf("%s", b.llm)
+	}
+	return strings.Join(names, " / ")
+}
+
+func (r *Router) MaxTokens() int {
+	var max int
+	for _, b := range r.backends {
+		if n := b.llm.MaxTokens(); n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+func (r *Router) EstimateTokens(messages []Message) (TokenCount, error) {
+	return r.backends[0].llm.EstimateTokens(messages)
+}
+
+func (r *Router) Streaming(messages []Message, stream io.Writer) (*Response, error) {
+	events, err := r.StreamingEvents(context.Background(), messages)
+	if err != nil {
+		return nil, err
+	}
+	return collectEvents(events, stream)
+}
+
+// StreamingWithTools buffers each attempt's output instead of writing it
+// straight to stream: if a backend fails mid-generation after already
+// emitting text, failing over and writing the next backend's full answer
+// into the same stream would garble it with the abandoned partial output.
+// Only the attempt that succeeds gets its buffered bytes copied to stream.
+func (r *Router) StreamingWithTools(messages []Message, tools *ToolRegistry, stream io.Writer) (*Response, error) {
+	var buf bytes.Buffer
+	resp, err := r.do(messages, func(b LLMInterface) (*Response, error) {
+		buf.Reset()
+		return b.StreamingWithTools(messages, tools, &buf)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stream.Write(buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StreamingEvents tries each eligible backend's own StreamingEvents, in
+// policy order, falling over to the next on a setup-time error exactly like
+// do. Once a backend's stream has started, the Router can no longer silently
+// retry elsewhere (the caller may already be consuming events), so the
+// backend's rolling health signal is instead updated once the stream reaches
+// its terminal event.
+func (r *Router) StreamingEvents(ctx context.Context, messages []Message) (<-chan StreamEvent, error) {
+	candidates := r.candidates(messages)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no healthy backend within token budget (of %d)", len(r.backends))
+	}
+	var lastErr error
+	for _, b := range candidates {
+		start := time.Now()
+		events, err := b.llm.StreamingEvents(ctx, messages)
+		if err != nil {
+			lastErr = err
+			b.recordFailure(time.Since(start), isTransient(err))
+			continue
+		}
+		return r.trackEvents(b, start, events), nil
+	}
+	return nil, fmt.Errorf("all %d eligible backend(s) failed, last error: %w", len(candidates), lastErr)
+}
+
+// trackEvents relays events unchanged, recording the backend's outcome once a
+// FinishEvent or StreamError comes through.
+func (r *Router) trackEvents(b *routedBackend, start time.Time, events <-chan StreamEvent) <-chan StreamEvent {
+	out := make(chan StreamEvent)
+	go func() {
+		defer close(out)
+		for e := range events {
+			out <- e
+			switch v := e.(type) {
+			case FinishEvent:
+				b.recordSuccess(time.Since(start))
+			case StreamError:
+				b.recordFailure(time.Since(start), isTransient(v.Err))
+			}
+		}
+	}()
+	return out
+}
+
+// do tries each eligible backend, in policy order, until one succeeds or none
+// remain. A transient error on a backend backs it off exponentially and moves
+// on to the next candidate instead of aborting the whole call.
+func (r *Router) do(messages []Message, call func(LLMInterface) (*Response, error)) (*Response, error) {
+	candidates := r.candidates(messages)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no healthy backend within token budget (of %d)", len(r.backends))
+	}
+	var lastErr error
+	for _, b := range candidates {
+		start := time.Now()
+		resp, err := call(b.llm)
+		latency := time.Since(start)
+		if err != nil {
+			lastErr = err
+			b.recordFailure(latency, isTransient(err))
+			continue
+		}
+		b.recordSuccess(latency)
+		return resp, nil
+	}
+	return nil, fmt.Errorf("all %d eligible backend(s) failed, last error: %w", len(candidates), lastErr)
+}
+
+// candidates returns the backends that currently fit messages within their
+// token budget and aren't backed off, ordered per the Router's policy.
+//
+// r.backends is fixed at construction, and b.healthy()/EstimateTokens only
+// touch per-backend state, so none of this needs r.mu — some estimators
+// (e.g. Gemini's, which calls its countTokens REST endpoint) do network I/O,
+// and holding the router-wide mutex across that would serialize every
+// in-flight call behind it. The mutex is only taken for the RoundRobin
+// cursor, which is genuinely shared Router state.
+func (r *Router) candidates(messages []Message) []*routedBackend {
+	var eligible []*routedBackend
+	var healthy []*routedBackend
+	for _, b := range r.backends {
+		if !b.healthy() {
+			continue
+		}
+		healthy = append(healthy, b)
+		n, err := b.llm.EstimateTokens(messages)
+		if err != nil {
+			continue
+		}
+		const headroom = 1000
+		if n.Total+headroom > b.llm.MaxTokens() {
+			continue
+		}
+		eligible = append(eligible, b)
+	}
+	if len(eligible) == 0 && r.policy.FallbackOverBudget && len(healthy) > 0 {
+		// nothing fits: fall back to the healthy backend with the largest
+		// budget, on the theory that it's the least-bad option available.
+		best := healthy[0]
+		for _, b := range healthy[1:] {
+			if b.llm.MaxTokens() > best.llm.MaxTokens() {
+				best = b
+			}
+		}
+		eligible = []*routedBackend{best}
+	}
+
+	switch r.policy.Mode {
+	case LatencyWeighted:
+		sort.SliceStable(eligible, func(i, j int) bool {
+			return eligible[i].p50() < eligible[j].p50()
+		})
+	case RoundRobin:
+		if len(eligible) > 0 {
+			r.mu.Lock()
+			r.rrCursor = (r.rrCursor + 1) % len(eligible)
+			cursor := r.rrCursor
+			r.mu.Unlock()
+			eligible = append(eligible[cursor:], eligible[:cursor]...)
+		}
+	case PriorityOrder:
+		// already in priority (registration) order
+	}
+	return eligible
+}
+
+// Stats is a point-in-time snapshot of a single backend's observed health.
+type Stats struct {
+	Backend             string
+	Healthy             bool
+	ErrorRate           float64
+	P50, P95            time.Duration
+	ConsecutiveFailures int
+	BackoffUntil        time.Time
+}
+
+// Stats returns a snapshot of every backend's rolling health signal, in
+// registration order.
+func (r *Router) Stats() []Stats {
+	out := make([]Stats, len(r.backends))
+	for i, b := range r.backends {
+		out[i] = b.stats()
+	}
+	return out
+}
+
+const (
+	healthWindow          = 20 // outcomes retained per backend
+	maxConsecutiveFailure = 5
+	baseBackoff           = time.Second
+	maxBackoff            = time.Minute
+)
+
+type outcome struct {
+	at      time.Time
+	latency time.Duration
+	failed  bool
+}
+
+type routedBackend struct {
+	llm LLMInterface
+
+	mu                  sync.Mutex
+	window              []outcome
+	consecutiveFailures int
+	backoffUntil        time.Time
+}
+
+func (b *routedBackend) healthy() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.backoffUntil)
+}
+
+func (b *routedBackend) recordSuccess(latency time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFailures = 0
+	b.backoffUntil = time.Time{}
+	b.push(outcome{at: time.Now(), latency: latency})
+}
+
+func (b *routedBackend) recordFailure(latency time.Duration, transient bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.push(outcome{at: time.Now(), latency: latency, failed: true})
+	if !transient {
+		return
+	}
+	b.consecutiveFailures++
+	n := b.consecutiveFailures
+	if n > maxConsecutiveFailure {
+		n = maxConsecutiveFailure
+	}
+	backoff := baseBackoff * time.Duration(math.Pow(2, float64(n-1)))
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	b.backoffUntil = time.Now().Add(backoff)
+}
+
+func (b *routedBackend) push(o outcome) {
+	b.window = append(b.window, o)
+	if len(b.window) > healthWindow {
+		b.window = b.window[len(b.window)-healthWindow:]
+	}
+}
+
+func (b *routedBackend) p50() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.percentileLocked(0.5)
+}
+
+func (b *routedBackend) percentile(p float64) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.percentileLocked(p)
+}
+
+// percentileLocked is percentile's body, callable by a method that already
+// holds b.mu (stats() needs two percentiles under one lock, and sync.Mutex
+// isn't reentrant, so percentile itself can't be called while holding the lock).
+func (b *routedBackend) percentileLocked(p float64) time.Duration {
+	if len(b.window) == 0 {
+		return 0
+	}
+	latencies := make([]time.Duration, len(b.window))
+	for i, o := range b.window {
+		latencies[i] = o.latency
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	idx := int(p * float64(len(latencies)-1))
+	return latencies[idx]
+}
+
+func (b *routedBackend) stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var failures int
+	for _, o := range b.window {
+		if o.failed {
+			failures++
+		}
+	}
+	var errRate float64
+	if len(b.window) > 0 {
+		errRate = float64(failures) / float64(len(b.window))
+	}
+	return Stats{
+		Backend:             fmt.Sprintf("%s", b.llm),
+		Healthy:             time.Now().After(b.backoffUntil),
+		ErrorRate:           errRate,
+		P50:                 b.percentileLocked(0.5),
+		P95:                 b.percentileLocked(0.95),
+		ConsecutiveFailures: b.consecutiveFailures,
+		BackoffUntil:        b.backoffUntil,
+	}
+}
+
+// isTransient reports whether err looks like a retryable, backend-side
+// failure (HTTP 5xx, rate limiting/throttling, or a context-length overflow)
+// rather than a permanent one.
+func isTransient(err error) bool {
+	s := strings.ToLower(err.Error())
+	for _, marker := range []string{
+		"500", "502", "503", "504",
+		"throttl", "rate limit", "too many requests",
+		"context length", "context_length_exceeded", "maximum context length",
+	} {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
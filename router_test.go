@@ -0,0 +1,156 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+// fakeLLM is a minimal LLMInterface stub for exercising Router's candidate
+// selection without a real backend.
+type fakeLLM struct {
+	name      string
+	maxTokens int
+	estimate  int
+}
+
+func (f fakeLLM) String() string { return f.name }
+
+func (f fakeLLM) MaxTokens() int { return f.maxTokens }
+
+func (f fakeLLM) EstimateTokens(messages []Message) (TokenCount, error) {
+	return TokenCount{Total: f.estimate}, nil
+}
+
+func (f fakeLLM) Streaming(messages []Message, stream io.Writer) (*Response, error) {
+	return &Response{Content: f.name}, nil
+}
+
+func (f fakeLLM) StreamingWithTools(messages []Message, tools *ToolRegistry, stream io.Writer) (*Response, error) {
+	return &Response{Content: f.name}, nil
+}
+
+func (f fakeLLM) StreamingEvents(ctx context.Context, messages []Message) (<-chan StreamEvent, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestRouterCandidatesExcludesOverBudgetByDefault(t *testing.T) {
+	small := fakeLLM{name: "small", maxTokens: 1000, estimate: 2000}
+	large := fakeLLM{name: "large", maxTokens: 1500, estimate: 2000}
+	r, err := NewRouter(RouterPolicy{Mode: PriorityOrder}, small, large)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.candidates(nil); len(got) != 0 {
+		t.Fatalf("candidates = %v, want none (both over budget)", got)
+	}
+}
+
+func TestMultiInterfaceFallsBackWhenBothOverBudget(t *testing.T) {
+	small := fakeLLM{name: "small", maxTokens: 1000, estimate: 2000}
+	large := fakeLLM{name: "large", maxTokens: 1500, estimate: 2000}
+	llm, err := NewMultiInterface(small, large)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := llm.(*Router)
+	got := r.candidates(nil)
+	if len(got) != 1 {
+		t.Fatalf("candidates = %v, want exactly one best-effort fallback", got)
+	}
+	if got[0].llm.(fakeLLM).name != "large" {
+		t.Fatalf("fell back to %q, want the larger backend", got[0].llm.(fakeLLM).name)
+	}
+}
+
+func TestRouterStatsDoesNotDeadlock(t *testing.T) {
+	small := fakeLLM{name: "small", maxTokens: 2000, estimate: 10}
+	r, err := NewRouter(RouterPolicy{Mode: PriorityOrder}, small)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.backends[0].recordSuccess(5 * time.Millisecond)
+	r.backends[0].recordFailure(10*time.Millisecond, true)
+
+	done := make(chan []Stats, 1)
+	go func() { done <- r.Stats() }()
+	select {
+	case stats := <-done:
+		if len(stats) != 1 {
+			t.Fatalf("len(stats) = %d, want 1", len(stats))
+		}
+		if stats[0].ErrorRate != 0.5 {
+			t.Fatalf("ErrorRate = %v, want 0.5", stats[0].ErrorRate)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Stats() deadlocked")
+	}
+}
+
+// flakyLLM is an LLMInterface stub whose StreamingWithTools writes partial
+// output to the stream before failing, so tests can exercise Router's
+// failover behavior against a shared writer.
+type flakyLLM struct {
+	name    string
+	partial string
+	fail    bool
+	final   string
+}
+
+func (f flakyLLM) String() string { return f.name }
+
+func (f flakyLLM) MaxTokens() int { return 2000 }
+
+func (f flakyLLM) EstimateTokens(messages []Message) (TokenCount, error) {
+	return TokenCount{Total: 10}, nil
+}
+
+func (f flakyLLM) Streaming(messages []Message, stream io.Writer) (*Response, error) {
+	return &Response{Content: f.name}, nil
+}
+
+func (f flakyLLM) StreamingWithTools(messages []Message, tools *ToolRegistry, stream io.Writer) (*Response, error) {
+	io.WriteString(stream, f.partial)
+	if f.fail {
+		return nil, fmt.Errorf("500 internal server error")
+	}
+	io.WriteString(stream, f.final)
+	return &Response{Content: f.partial + f.final}, nil
+}
+
+func (f flakyLLM) StreamingEvents(ctx context.Context, messages []Message) (<-chan StreamEvent, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestRouterStreamingWithToolsDiscardsFailedAttemptOutput(t *testing.T) {
+	first := flakyLLM{name: "first", partial: "PARTIAL ", fail: true}
+	second := flakyLLM{name: "second", final: "FULL ANSWER"}
+	r, err := NewRouter(RouterPolicy{Mode: PriorityOrder}, first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := r.StreamingWithTools(nil, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "FULL ANSWER" {
+		t.Fatalf("stream = %q, want only the successful backend's output", got)
+	}
+}
+
+func TestMultiInterfacePrefersInBudgetBackend(t *testing.T) {
+	small := fakeLLM{name: "small", maxTokens: 2000, estimate: 10}
+	large := fakeLLM{name: "large", maxTokens: 2500, estimate: 2000}
+	llm, err := NewMultiInterface(small, large)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := llm.(*Router)
+	got := r.candidates(nil)
+	if len(got) != 1 || got[0].llm.(fakeLLM).name != "small" {
+		t.Fatalf("len(candidates) = %d, want exactly the in-budget small backend", len(got))
+	}
+}
@@ -0,0 +1,363 @@
+package chat
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	_ "modernc.org/sqlite"
+)
+
+// Store persists conversations as a parent-pointer tree of Messages, not a
+// flat list, so editing an earlier message forks a new branch instead of
+// discarding what came after it. Branches track a HEAD message so the active
+// path can be reconstructed without walking the whole tree.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore opens (creating if necessary) a SQLite-backed Store at path.
+func NewStore(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(storeSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("can't initialize schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+const storeSchema = `
+create table if not exists conversations (
+	id text primary key,
+	title text not null,
+	current_branch_id text,
+	created_at datetime not null
+);
+create table if not exists branches (
+	id text primary key,
+	conversation_id text not null,
+	name text not null,
+	head_message_id text
+);
+create table if not exists messages (
+	id text primary key,
+	conversation_id text not null,
+	parent_id text,
+	role integer not null,
+	content text not null,
+	model text,
+	tool_call_id text,
+	tool_name text,
+	usage json,
+	created_at datetime not null
+);
+`
+
+// Conversation is a named, persisted exchange with an LLM.
+type Conversation struct {
+	ID              string
+	Title           string
+	CurrentBranchID string
+	CreatedAt       time.Time
+}
+
+// Branch is a named HEAD pointer into a Conversation's message tree.
+type Branch struct {
+	ID             string
+	ConversationID string
+	Name           string
+	Head           string // message id, empty if the branch has no messages yet
+}
+
+// StoredMessage is a Message as persisted in the tree, along with the pointers
+// needed to locate and fork it.
+type StoredMessage struct {
+	Message
+	ID             string
+	ConversationID string
+	ParentID       string // empty for the root of a conversation
+	Model          string // the LLMInterface that generated this turn; empty for non-assistant messages
+	Usage          TokenCount
+	CreatedAt      time.Time
+}
+
+const mainBranch = "main"
+
+// New starts a fresh Conversation with a single empty "main" branch.
+func (s *Store) New(title string) (Conversation, error) {
+	c := Conversation{
+		ID:        uuid.NewString(),
+		Title:     title,
+		CreatedAt: time.Now(),
+	}
+	b := Branch{ID: uuid.NewString(), ConversationID: c.ID, Name: mainBranch}
+	c.CurrentBranchID = b.ID
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Conversation{}, err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`insert into conversations (id, title, current_branch_id, created_at) values (?, ?, ?, ?)`,
+		c.ID, c.Title, c.CurrentBranchID, c.CreatedAt); err != nil {
+		return Conversation{}, err
+	}
+	if _, err := tx.Exec(`insert into branches (id, conversation_id, name, head_message_id) values (?, ?, ?, null)`,
+		b.ID, b.ConversationID, b.Name); err != nil {
+		return Conversation{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Conversation{}, err
+	}
+	return c, nil
+}
+
+// Open loads a Conversation by id.
+func (s *Store) Open(id string) (Conversation, error) {
+	var c Conversation
+	row := s.db.QueryRow(`select id, title, current_branch_id, created_at from conversations where id = ?`, id)
+	if err := row.Scan(&c.ID, &c.Title, &c.CurrentBranchID, &c.CreatedAt); err != nil {
+		return Conversation{}, fmt.Errorf("can't open conversation %q: %w", id, err)
+	}
+	return c, nil
+}
+
+// List returns every Conversation, most recently created first.
+func (s *Store) List() ([]Conversation, error) {
+	rows, err := s.db.Query(`select id, title, current_branch_id, created_at from conversations order by created_at desc`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Conversation
+	for rows.Next() {
+		var c Conversation
+		if err := rows.Scan(&c.ID, &c.Title, &c.CurrentBranchID, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// Remove deletes a Conversation and everything in its message tree.
+func (s *Store) Remove(conversationID string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`delete from messages where conversation_id = ?`, conversationID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`delete from branches where conversation_id = ?`, conversationID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`delete from conversations where id = ?`, conversationID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Branches lists every Branch of a Conversation.
+func (s *Store) Branches(conversationID string) ([]Branch, error) {
+	rows, err := s.db.Query(`select id, conversation_id, name, coalesce(head_message_id, '') from branches where conversation_id = ?`, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Branch
+	for rows.Next() {
+		var b Branch
+		if err := rows.Scan(&b.ID, &b.ConversationID, &b.Name, &b.Head); err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+// Checkout makes the named Branch the Conversation's active one, so View and
+// Reply/Append operate on it. name is not guaranteed unique (it's meant for
+// humans, not lookups); if more than one branch shares it, Checkout resolves
+// to whichever row the database returns first. Use CheckoutID when the
+// branch ID is known, e.g. as returned by Edit.
+func (s *Store) Checkout(conversationID, branchName string) error {
+	var branchID string
+	row := s.db.QueryRow(`select id from branches where conversation_id = ? and name = ?`, conversationID, branchName)
+	if err := row.Scan(&branchID); err != nil {
+		return fmt.Errorf("can't find branch %q of conversation %q: %w", branchName, conversationID, err)
+	}
+	return s.CheckoutID(conversationID, branchID)
+}
+
+// CheckoutID makes the Branch identified by branchID the Conversation's
+// active one. Unlike Checkout, branchID is always unique, so this can't
+// resolve to the wrong branch.
+func (s *Store) CheckoutID(conversationID, branchID string) error {
+	_, err := s.db.Exec(`update conversations set current_branch_id = ? where id = ?`, branchID, conversationID)
+	return err
+}
+
+// View reconstructs the active path of a Conversation, root first.
+func (s *Store) View(conversationID string) ([]StoredMessage, error) {
+	c, err := s.Open(conversationID)
+	if err != nil {
+		return nil, err
+	}
+	var head sql.NullString
+	row := s.db.QueryRow(`select head_message_id from branches where id = ?`, c.CurrentBranchID)
+	if err := row.Scan(&head); err != nil {
+		return nil, err
+	}
+	var path []StoredMessage
+	id := head
+	for id.Valid && len(id.String) > 0 {
+		m, err := s.loadMessage(id.String)
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, m)
+		if len(m.ParentID) == 0 {
+			break
+		}
+		id = sql.NullString{String: m.ParentID, Valid: true}
+	}
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+	return path, nil
+}
+
+func (s *Store) loadMessage(id string) (StoredMessage, error) {
+	var m StoredMessage
+	var parentID, model, toolCallID, toolName sql.NullString
+	var usage sql.NullString
+	var role int
+	row := s.db.QueryRow(`select id, conversation_id, parent_id, role, content, model, tool_call_id, tool_name, usage, created_at from messages where id = ?`, id)
+	if err := row.Scan(&m.ID, &m.ConversationID, &parentID, &role, &m.Content, &model, &toolCallID, &toolName, &usage, &m.CreatedAt); err != nil {
+		return StoredMessage{}, fmt.Errorf("can't load message %q: %w", id, err)
+	}
+	m.Role = Role(role)
+	m.ParentID = parentID.String
+	m.Model = model.String
+	m.ToolCallID = toolCallID.String
+	m.ToolName = toolName.String
+	if usage.Valid {
+		json.Unmarshal([]byte(usage.String), &m.Usage)
+	}
+	return m, nil
+}
+
+// Reply appends a RoleUser Message with the given text to the active branch.
+func (s *Store) Reply(conversationID, text string) (StoredMessage, error) {
+	return s.Append(conversationID, Message{Role: RoleUser, Content: text}, TokenCount{}, "")
+}
+
+// Append adds m as the new HEAD of the Conversation's active branch, parented
+// on the branch's current HEAD. usage is recorded alongside assistant turns so
+// a conversation's running cost can be reconstructed from its history. model
+// is the LLMInterface that generated m; pass "" for non-assistant messages.
+func (s *Store) Append(conversationID string, m Message, usage TokenCount, model string) (StoredMessage, error) {
+	c, err := s.Open(conversationID)
+	if err != nil {
+		return StoredMessage{}, err
+	}
+	var parent sql.NullString
+	row := s.db.QueryRow(`select head_message_id from branches where id = ?`, c.CurrentBranchID)
+	if err := row.Scan(&parent); err != nil {
+		return StoredMessage{}, err
+	}
+	return s.appendTo(c.CurrentBranchID, parent.String, conversationID, m, usage, model)
+}
+
+func (s *Store) appendTo(branchID, parentID, conversationID string, m Message, usage TokenCount, model string) (StoredMessage, error) {
+	stored := StoredMessage{
+		Message:        m,
+		ID:             uuid.NewString(),
+		ConversationID: conversationID,
+		ParentID:       parentID,
+		Model:          model,
+		CreatedAt:      time.Now(),
+	}
+	usageJSON, err := json.Marshal(usage)
+	if err != nil {
+		return StoredMessage{}, err
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return StoredMessage{}, err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`insert into messages (id, conversation_id, parent_id, role, content, model, tool_call_id, tool_name, usage, created_at) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		stored.ID, stored.ConversationID, nullIfEmpty(stored.ParentID), int(m.Role), m.Content, nullIfEmpty(stored.Model), nullIfEmpty(m.ToolCallID), nullIfEmpty(m.ToolName), string(usageJSON), stored.CreatedAt); err != nil {
+		return StoredMessage{}, err
+	}
+	if _, err := tx.Exec(`update branches set head_message_id = ? where id = ?`, stored.ID, branchID); err != nil {
+		return StoredMessage{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return StoredMessage{}, err
+	}
+	return stored, nil
+}
+
+// Edit forks the Conversation into a new branch rooted at a copy of msgID with
+// newContent in place of its original content, leaving every existing branch
+// (and msgID itself) untouched. The new branch becomes the Conversation's
+// active one, so the next Reply/Append continues from the edited message.
+func (s *Store) Edit(msgID, newContent string) (newBranchID string, err error) {
+	orig, err := s.loadMessage(msgID)
+	if err != nil {
+		return "", err
+	}
+	branchID := uuid.NewString()
+	branch := Branch{
+		ID:             branchID,
+		ConversationID: orig.ConversationID,
+		// Suffixed with the branch's own ID, not just the edited message's, so
+		// editing the same message twice doesn't produce two same-named
+		// branches: name alone isn't a reliable way to find a branch back.
+		Name: fmt.Sprintf("edit-%s-%s", orig.ID[:8], branchID[:8]),
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`insert into branches (id, conversation_id, name, head_message_id) values (?, ?, ?, null)`,
+		branch.ID, branch.ConversationID, branch.Name); err != nil {
+		return "", err
+	}
+	forked := Message{Role: orig.Role, Content: newContent}
+	usage, err := json.Marshal(TokenCount{})
+	if err != nil {
+		return "", err
+	}
+	forkedID := uuid.NewString()
+	if _, err := tx.Exec(`insert into messages (id, conversation_id, parent_id, role, content, model, tool_call_id, tool_name, usage, created_at) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		forkedID, orig.ConversationID, nullIfEmpty(orig.ParentID), int(forked.Role), forked.Content, nullIfEmpty(orig.Model), nil, nil, string(usage), time.Now()); err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec(`update branches set head_message_id = ? where id = ?`, forkedID, branch.ID); err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec(`update conversations set current_branch_id = ? where id = ?`, branch.ID, orig.ConversationID); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	return branch.ID, nil
+}
+
+func nullIfEmpty(s string) any {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
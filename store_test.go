@@ -0,0 +1,145 @@
+package chat
+
+import (
+	"testing"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestStoreAppendViewRoundTrip(t *testing.T) {
+	s := newTestStore(t)
+	c, err := s.New("test conversation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Reply(c.ID, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	usage := TokenCount{Prompt: 10, Completion: 5, Total: 15}
+	if _, err := s.Append(c.ID, Message{Role: RoleAssistant, Content: "hi there"}, usage, "claude2"); err != nil {
+		t.Fatal(err)
+	}
+	path, err := s.View(c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(path) != 2 {
+		t.Fatalf("len(path) = %d, want 2", len(path))
+	}
+	if path[0].Role != RoleUser || path[0].Content != "hello" {
+		t.Fatalf("path[0] = %+v, want RoleUser %q", path[0], "hello")
+	}
+	assistant := path[1]
+	if assistant.Role != RoleAssistant || assistant.Content != "hi there" {
+		t.Fatalf("path[1] = %+v, want RoleAssistant %q", assistant, "hi there")
+	}
+	if assistant.Model != "claude2" {
+		t.Fatalf("Model = %q, want %q", assistant.Model, "claude2")
+	}
+	if assistant.Usage != usage {
+		t.Fatalf("Usage = %+v, want %+v", assistant.Usage, usage)
+	}
+}
+
+func TestStoreEditForksWithoutAlteringOriginalBranch(t *testing.T) {
+	s := newTestStore(t)
+	c, err := s.New("test conversation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := s.Reply(c.ID, "original")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Reply(c.ID, "follow-up"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Edit(first.ID, "edited"); err != nil {
+		t.Fatal(err)
+	}
+
+	editedPath, err := s.View(c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(editedPath) != 1 || editedPath[0].Content != "edited" {
+		t.Fatalf("editedPath = %+v, want a single message with content %q", editedPath, "edited")
+	}
+
+	if err := s.Checkout(c.ID, mainBranch); err != nil {
+		t.Fatal(err)
+	}
+	mainPath, err := s.View(c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mainPath) != 2 || mainPath[0].Content != "original" || mainPath[1].Content != "follow-up" {
+		t.Fatalf("mainPath = %+v, want original branch unchanged", mainPath)
+	}
+}
+
+func TestStoreEditingSameMessageTwiceKeepsBranchesDistinct(t *testing.T) {
+	s := newTestStore(t)
+	c, err := s.New("test conversation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := s.Reply(c.ID, "original")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstEditBranch, err := s.Edit(first.ID, "edit one")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondEditBranch, err := s.Edit(first.ID, "edit two")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstEditBranch == secondEditBranch {
+		t.Fatalf("Edit returned the same branch ID twice: %q", firstEditBranch)
+	}
+
+	branches, err := s.Branches(c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, b := range branches {
+		if names[b.Name] {
+			t.Fatalf("branches = %+v, want unique names", branches)
+		}
+		names[b.Name] = true
+	}
+
+	if err := s.CheckoutID(c.ID, firstEditBranch); err != nil {
+		t.Fatal(err)
+	}
+	path, err := s.View(c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(path) != 1 || path[0].Content != "edit one" {
+		t.Fatalf("path = %+v, want a single message with content %q", path, "edit one")
+	}
+
+	if err := s.CheckoutID(c.ID, secondEditBranch); err != nil {
+		t.Fatal(err)
+	}
+	path, err = s.View(c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(path) != 1 || path[0].Content != "edit two" {
+		t.Fatalf("path = %+v, want a single message with content %q", path, "edit two")
+	}
+}
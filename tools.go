@@ -0,0 +1,104 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/alecthomas/jsonschema"
+)
+
+// Tool describes a Go function the model may invoke mid-stream, along with the
+// JSON-Schema describing its input, so it can be offered to an LLMInterface's
+// StreamingWithTools.
+type Tool struct {
+	Name        string
+	Description string
+	Schema      *jsonschema.Schema
+	Handler     func(ctx context.Context, input json.RawMessage) (json.RawMessage, error)
+}
+
+// NewTool builds a Tool whose Schema is reflected from the Go type of its argument,
+// so callers don't have to hand-write JSON-Schema for simple structs.
+func NewTool[T any](name, description string, handler func(ctx context.Context, args T) (json.RawMessage, error)) Tool {
+	r := &jsonschema.Reflector{ExpandedStruct: true}
+	schema := r.Reflect(new(T))
+	return Tool{
+		Name:        name,
+		Description: description,
+		Schema:      schema,
+		Handler: func(ctx context.Context, raw json.RawMessage) (json.RawMessage, error) {
+			var args T
+			if len(raw) > 0 {
+				if err := json.Unmarshal(raw, &args); err != nil {
+					return nil, fmt.Errorf("can't parse arguments for tool %q: %w", name, err)
+				}
+			}
+			return handler(ctx, args)
+		},
+	}
+}
+
+// ToolCall is a single tool invocation the model asked for.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments json.RawMessage
+}
+
+// ToolResult is the outcome of running a ToolCall, round-tripped back to the
+// model as a RoleTool Message.
+type ToolResult struct {
+	ID      string
+	Name    string
+	Content json.RawMessage
+	Error   string
+}
+
+// ToolRegistry indexes a set of Tools by name for dispatch during a streaming
+// exchange with tool-calling enabled.
+type ToolRegistry struct {
+	tools map[string]Tool
+	order []string
+}
+
+// NewToolRegistry builds a ToolRegistry from the given Tools, preserving the order
+// they were passed in (used when listing tools for a backend).
+func NewToolRegistry(tools ...Tool) *ToolRegistry {
+	r := &ToolRegistry{tools: make(map[string]Tool)}
+	for _, t := range tools {
+		if _, ok := r.tools[t.Name]; ok {
+			panic("duplicate tool: " + t.Name)
+		}
+		r.tools[t.Name] = t
+		r.order = append(r.order, t.Name)
+	}
+	return r
+}
+
+// List returns the registered Tools in registration order.
+func (r *ToolRegistry) List() []Tool {
+	if r == nil {
+		return nil
+	}
+	out := make([]Tool, len(r.order))
+	for i, n := range r.order {
+		out[i] = r.tools[n]
+	}
+	return out
+}
+
+// Call runs the named tool's handler, turning an unknown tool or handler error
+// into a ToolResult.Error so the model can see and recover from it instead of
+// aborting the whole exchange.
+func (r *ToolRegistry) Call(ctx context.Context, call ToolCall) ToolResult {
+	t, ok := r.tools[call.Name]
+	if !ok {
+		return ToolResult{ID: call.ID, Name: call.Name, Error: fmt.Sprintf("unknown tool %q", call.Name)}
+	}
+	out, err := t.Handler(ctx, call.Arguments)
+	if err != nil {
+		return ToolResult{ID: call.ID, Name: call.Name, Error: err.Error()}
+	}
+	return ToolResult{ID: call.ID, Name: call.Name, Content: out}
+}
@@ -0,0 +1,41 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+type weatherArgs struct {
+	Location string `json:"location"`
+}
+
+func getWeatherTool() Tool {
+	return NewTool("get_weather", "get the current weather for a location", func(ctx context.Context, args weatherArgs) (json.RawMessage, error) {
+		return json.Marshal(map[string]any{"location": args.Location, "tempF": 72})
+	})
+}
+
+func TestToolRegistryCallDispatchesToNamedTool(t *testing.T) {
+	registry := NewToolRegistry(getWeatherTool())
+	call := ToolCall{ID: "call_1", Name: "get_weather", Arguments: json.RawMessage(`{"location":"boston"}`)}
+	result := registry.Call(context.Background(), call)
+	if result.Error != "" {
+		t.Fatalf("unexpected error: %s", result.Error)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(result.Content, &out); err != nil {
+		t.Fatalf("can't parse result content: %v", err)
+	}
+	if out["location"] != "boston" {
+		t.Fatalf("location = %v, want boston", out["location"])
+	}
+}
+
+func TestToolRegistryCallUnknownTool(t *testing.T) {
+	registry := NewToolRegistry(getWeatherTool())
+	result := registry.Call(context.Background(), ToolCall{ID: "call_1", Name: "get_time"})
+	if result.Error == "" {
+		t.Fatal("expected an error for an unregistered tool")
+	}
+}